@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	ghttp "google.golang.org/api/transport/http"
+)
+
+// NewADCService creates a Service instance using Application Default Credentials (ADC) instead of an
+// explicit service account key file.
+//
+// Credentials are resolved, in order, from: the GOOGLE_APPLICATION_CREDENTIALS environment variable,
+// the `gcloud auth application-default login` user credentials, and finally the GCE/Cloud Run/Cloud
+// Functions metadata server. See google.FindDefaultCredentials for the full resolution order.
+//
+// This is the recommended way to authenticate when the binary runs on Google-managed infrastructure
+// or on a developer machine with `gcloud` already configured, since no JSON key needs to be shipped
+// alongside it.
+func NewADCService(ctx context.Context, opts ...ServiceOption) (*Service, error) {
+	cfg := &ServiceConfig{
+		Scopes:         GoogleSheetsReadWrite,
+		UniverseDomain: DefaultUniverseDomain,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	creds, err := google.FindDefaultCredentials(getClientCtx(cfg.HTTPClient), cfg.Scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateUniverseDomain(creds, cfg.UniverseDomain); err != nil {
+		return nil, err
+	}
+
+	// option.WithUniverseDomain makes ghttp.NewClient check the credentials against the configured
+	// universe. The endpoint it resolves for that universe is discarded: the returned client carries
+	// no API host, so requests go to whatever endpoint the Sheets service is built with.
+	client, _, err := ghttp.NewClient(
+		getClientCtx(cfg.HTTPClient),
+		option.WithScopes(cfg.Scopes...),
+		option.WithCredentials(creds),
+		option.WithUniverseDomain(cfg.UniverseDomain),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		googleAuthClient: client,
+	}, nil
+}
+
+func validateUniverseDomain(creds *google.Credentials, want string) error {
+	got, err := creds.GetUniverseDomain()
+	if err != nil {
+		return fmt.Errorf("failed resolving the credentials' universe domain: %w", err)
+	}
+	if got != want {
+		return fmt.Errorf("credentials belong to universe domain %q, but the service was configured for %q", got, want)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2/google"
+)
+
+func testCredentials(t *testing.T) *google.Credentials {
+	t.Helper()
+
+	raw := []byte(`{
+		"type": "authorized_user",
+		"client_id": "test-client-id",
+		"client_secret": "test-client-secret",
+		"refresh_token": "test-refresh-token"
+	}`)
+	creds, err := google.CredentialsFromJSON(context.Background(), raw)
+	assert.Nil(t, err)
+	return creds
+}
+
+func TestValidateUniverseDomain_Match(t *testing.T) {
+	creds := testCredentials(t)
+	assert.Nil(t, validateUniverseDomain(creds, DefaultUniverseDomain))
+}
+
+func TestValidateUniverseDomain_Mismatch(t *testing.T) {
+	creds := testCredentials(t)
+	err := validateUniverseDomain(creds, "example.com")
+	assert.NotNil(t, err, "credentials resolve to the default universe domain, which does not match the configured one")
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// CodeExchanger is responsible for turning a Google OAuth2 consent URL into an authorization code.
+//
+// Implementations are free to automate this however they like: spinning up a local HTTP server to
+// catch the redirect, or simply printing the URL and prompting the user to paste the code back in.
+type CodeExchanger interface {
+	// Exchange is given the Google consent URL the user must visit, and must return the authorization
+	// code Google redirected back with once the user has granted access.
+	Exchange(ctx context.Context, authURL string) (string, error)
+}
+
+// NewOAuth2FromFile works exactly the same as NewOAuth2Client, but instead of taking the raw content
+// of the OAuth2 client secret, it reads it from "clientSecretPath".
+//
+// The "clientSecretPath" is referring to the `client_secret.json` file downloadable from
+// https://developers.google.com/identity/protocols/oauth2/native-app for a Desktop/Installed application.
+func NewOAuth2FromFile(ctx context.Context, clientSecretPath string, scopes Scopes, tokenPath string, exchanger CodeExchanger) (*Service, error) {
+	raw, err := os.ReadFile(clientSecretPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewOAuth2Client(ctx, raw, scopes, tokenPath, exchanger)
+}
+
+// NewOAuth2Client creates a Service instance authenticated as the end user (rather than a service
+// account), following the OAuth2 "installed application" flow.
+//
+// "clientSecret" is the raw content of the `client_secret.json` downloaded from the Google Cloud
+// console for a Desktop/Installed application. "tokenPath" is where the resulting user token will be
+// cached as JSON; if a token already exists there, it is loaded and reused (refreshing it transparently
+// when it expires) instead of going through the consent flow again.
+func NewOAuth2Client(ctx context.Context, clientSecret []byte, scopes Scopes, tokenPath string, exchanger CodeExchanger) (*Service, error) {
+	config, err := google.ConfigFromJSON(clientSecret, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := loadToken(tokenPath)
+	if err != nil {
+		token, err = fetchToken(ctx, config, exchanger)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveToken(tokenPath, token); err != nil {
+			return nil, err
+		}
+	}
+
+	tokenSource := config.TokenSource(ctx, token)
+	return &Service{
+		googleAuthClient: oauth2.NewClient(ctx, tokenSource),
+	}, nil
+}
+
+func fetchToken(ctx context.Context, config *oauth2.Config, exchanger CodeExchanger) (*oauth2.Token, error) {
+	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+
+	code, err := exchanger.Exchange(ctx, authURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed obtaining the authorization code: %w", err)
+	}
+
+	token, err := config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed exchanging the authorization code for a token: %w", err)
+	}
+	return token, nil
+}
+
+func loadToken(tokenPath string) (*oauth2.Token, error) {
+	raw, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{}
+	if err := json.Unmarshal(raw, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func saveToken(tokenPath string, token *oauth2.Token) error {
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(tokenPath, raw, 0600)
+}
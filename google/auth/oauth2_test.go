@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+type stubExchanger struct {
+	authURL string
+	code    string
+	err     error
+}
+
+func (s *stubExchanger) Exchange(ctx context.Context, authURL string) (string, error) {
+	s.authURL = authURL
+	return s.code, s.err
+}
+
+func TestLoadSaveToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token.json")
+
+	_, err := loadToken(tokenPath)
+	assert.NotNil(t, err, "loading a token that does not exist yet should fail")
+
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now()}
+	assert.Nil(t, saveToken(tokenPath, token))
+
+	loaded, err := loadToken(tokenPath)
+	assert.Nil(t, err)
+	assert.Equal(t, token.AccessToken, loaded.AccessToken)
+	assert.Equal(t, token.RefreshToken, loaded.RefreshToken)
+
+	raw, err := os.ReadFile(tokenPath)
+	assert.Nil(t, err)
+
+	var asMap map[string]interface{}
+	assert.Nil(t, json.Unmarshal(raw, &asMap))
+	assert.Equal(t, "access", asMap["access_token"])
+}
+
+func TestNewOAuth2Client_CachedToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token.json")
+
+	token := &oauth2.Token{AccessToken: "cached-access", RefreshToken: "cached-refresh", Expiry: time.Now().Add(time.Hour)}
+	assert.Nil(t, saveToken(tokenPath, token))
+
+	exchanger := &stubExchanger{code: "should-not-be-used"}
+	clientSecret := []byte(`{"installed":{"client_id":"id","client_secret":"secret","auth_uri":"https://accounts.google.com/o/oauth2/auth","token_uri":"https://oauth2.googleapis.com/token","redirect_uris":["http://localhost"]}}`)
+
+	service, err := NewOAuth2Client(context.Background(), clientSecret, []string{}, tokenPath, exchanger)
+	assert.Nil(t, err)
+	assert.NotNil(t, service.HTTPClient())
+	assert.Equal(t, "", exchanger.authURL, "the cached token should be used instead of going through the consent flow")
+}
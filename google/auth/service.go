@@ -9,6 +9,10 @@ import (
 	ghttp "google.golang.org/api/transport/http"
 )
 
+// DefaultUniverseDomain is the universe domain used by public Google Cloud, as opposed to a
+// Trusted Partner Cloud (TPC) or a Google Distributed Cloud (GDC) deployment.
+const DefaultUniverseDomain = "googleapis.com"
+
 // ServiceConfig defines a list of configurations that can be used to customise how the Google
 // service account authentication flow works.
 type ServiceConfig struct {
@@ -18,6 +22,12 @@ type ServiceConfig struct {
 
 	ServiceKeyPath string
 	Scopes         Scopes
+
+	// UniverseDomain is the Google Cloud universe the credentials belong to. It defaults to
+	// DefaultUniverseDomain (public Google Cloud). Set it when authenticating against a Trusted
+	// Partner Cloud (TPC) or Google Distributed Cloud (GDC) deployment. It is used by NewService and
+	// NewADCService; NewServiceFromFile and NewServiceFromJSON ignore it.
+	UniverseDomain string
 }
 
 // Service takes in service account relevant information and sets up *http.Client that can be used to access
@@ -66,9 +76,16 @@ func WithServiceKeyPath(p string) ServiceOption {
 	}
 }
 
+func WithUniverseDomain(domain string) ServiceOption {
+	return func(cfg *ServiceConfig) {
+		cfg.UniverseDomain = domain
+	}
+}
+
 func NewService(opts ...ServiceOption) (*Service, error) {
 	cfg := &ServiceConfig{
-		Scopes: GoogleSheetsReadWrite,
+		Scopes:         GoogleSheetsReadWrite,
+		UniverseDomain: DefaultUniverseDomain,
 	}
 	for _, opt := range opts {
 		opt(cfg)
@@ -78,6 +95,7 @@ func NewService(opts ...ServiceOption) (*Service, error) {
 		getClientCtx(cfg.HTTPClient),
 		option.WithScopes(cfg.Scopes...),
 		option.WithCredentialsFile(cfg.ServiceKeyPath),
+		option.WithUniverseDomain(cfg.UniverseDomain),
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,109 @@
+package sheets
+
+// CellFormat describes how a single column's cells should be rendered: its number format (e.g.
+// currency, date, percentage), alignment, and simple text styling.
+//
+// A zero-value CellFormat means "don't touch this property" — e.g. an empty NumberFormatPattern
+// leaves the cell's existing number format untouched.
+type CellFormat struct {
+	// NumberFormatType is one of the Sheets API number format types, e.g. "CURRENCY", "DATE",
+	// "PERCENT", "NUMBER". Left empty, the cell's number format type is untouched.
+	NumberFormatType string
+
+	// NumberFormatPattern is the format pattern to go along with NumberFormatType, e.g. "$#,##0.00"
+	// or "yyyy-mm-dd".
+	NumberFormatPattern string
+
+	// HorizontalAlignment is one of "LEFT", "CENTER", "RIGHT". Left empty, alignment is untouched.
+	HorizontalAlignment string
+
+	// Bold renders the cell's text in bold when true.
+	Bold bool
+
+	// BackgroundColor is an RGB hex string (e.g. "#F3F3F3"). Left empty, the background is untouched.
+	BackgroundColor string
+}
+
+var (
+	// CurrencyUSD formats a column as a right-aligned USD amount, e.g. "$1,234.56".
+	CurrencyUSD = CellFormat{NumberFormatType: "CURRENCY", NumberFormatPattern: "$#,##0.00", HorizontalAlignment: "RIGHT"}
+
+	// DateISO formats a column as an ISO-8601 date, e.g. "2023-01-31".
+	DateISO = CellFormat{NumberFormatType: "DATE", NumberFormatPattern: "yyyy-mm-dd"}
+
+	// Percent formats a column as a percentage, e.g. "12.34%".
+	Percent = CellFormat{NumberFormatType: "PERCENT", NumberFormatPattern: "0.00%"}
+
+	// BoldFormat renders a column's text in bold without touching its number format.
+	BoldFormat = CellFormat{Bold: true}
+
+	// HeaderFormat is applied once to a newly created sheet's header row: bold text so column names
+	// stand out from the data beneath them.
+	HeaderFormat = CellFormat{Bold: true}
+)
+
+// ColumnCellFormat pairs a CellFormat with the 0-indexed column it applies to, within a given row
+// range (inclusive start, exclusive end, following the Sheets API GridRange convention). A RowEnd
+// of 0 means "to the end of the sheet".
+type ColumnCellFormat struct {
+	ColumnIndex int
+	RowStart    int
+	RowEnd      int
+	Format      CellFormat
+}
+
+// CellFormatter turns a set of column formatting declarations into per-column ColumnCellFormat
+// entries, which FormatCells applies to a sheet through batchUpdate. Row value writes do not go
+// through a CellFormatter: they always use the plain values API and carry no formatting.
+type CellFormatter interface {
+	// Format returns the ColumnCellFormat entries that should be applied to the given sheet.
+	Format(sheetID int64) []ColumnCellFormat
+}
+
+// columnFormatter is the default CellFormatter, built from a static column-name -> CellFormat
+// mapping (e.g. GoogleSheetRowStoreConfig.ColumnFormats) plus each column's resolved sheet index.
+type columnFormatter struct {
+	formats  map[string]CellFormat
+	colIndex map[string]int
+	rowStart int
+	rowEnd   int
+}
+
+// NewColumnFormatter builds a CellFormatter from a column name -> CellFormat mapping, resolving
+// column names to their 0-indexed column position via colIndex. rowStart/rowEnd bound which rows
+// the format applies to (rowEnd == 0 means "to the end of the sheet").
+func NewColumnFormatter(formats map[string]CellFormat, colIndex map[string]int, rowStart, rowEnd int) CellFormatter {
+	return &columnFormatter{formats: formats, colIndex: colIndex, rowStart: rowStart, rowEnd: rowEnd}
+}
+
+func (f *columnFormatter) Format(sheetID int64) []ColumnCellFormat {
+	result := make([]ColumnCellFormat, 0, len(f.formats))
+	for col, format := range f.formats {
+		idx, ok := f.colIndex[col]
+		if !ok {
+			continue
+		}
+		result = append(result, ColumnCellFormat{ColumnIndex: idx, RowStart: f.rowStart, RowEnd: f.rowEnd, Format: format})
+	}
+	return result
+}
+
+// headerRowFormatter applies HeaderFormat to every column of the header row (row 0).
+type headerRowFormatter struct {
+	columnCount int
+}
+
+// HeaderRowFormatter returns a CellFormatter that bolds every column of the header row (row 0) of a
+// sheet with the given column count. Freezing the row itself is not a per-cell format — it's a sheet
+// property — so it is applied separately via sheetsWrapper.FreezeHeaderRow.
+func HeaderRowFormatter(columnCount int) CellFormatter {
+	return &headerRowFormatter{columnCount: columnCount}
+}
+
+func (f *headerRowFormatter) Format(sheetID int64) []ColumnCellFormat {
+	result := make([]ColumnCellFormat, 0, f.columnCount)
+	for i := 0; i < f.columnCount; i++ {
+		result = append(result, ColumnCellFormat{ColumnIndex: i, RowStart: 0, RowEnd: 1, Format: HeaderFormat})
+	}
+	return result
+}
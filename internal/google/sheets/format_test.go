@@ -0,0 +1,46 @@
+package sheets
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnFormatter_Format(t *testing.T) {
+	formats := map[string]CellFormat{
+		"balance": CurrencyUSD,
+		"dob":     DateISO,
+		"missing": Percent, // not present in colIndex, should be skipped
+	}
+	colIndex := map[string]int{"name": 0, "balance": 1, "dob": 2}
+
+	formatter := NewColumnFormatter(formats, colIndex, 1, 0)
+	result := formatter.Format(123)
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ColumnIndex < result[j].ColumnIndex })
+
+	assert.Equal(t, []ColumnCellFormat{
+		{ColumnIndex: 1, RowStart: 1, RowEnd: 0, Format: CurrencyUSD},
+		{ColumnIndex: 2, RowStart: 1, RowEnd: 0, Format: DateISO},
+	}, result)
+}
+
+func TestHeaderRowFormatter_Format(t *testing.T) {
+	formatter := HeaderRowFormatter(3)
+	result := formatter.Format(123)
+
+	assert.Equal(t, []ColumnCellFormat{
+		{ColumnIndex: 0, RowStart: 0, RowEnd: 1, Format: HeaderFormat},
+		{ColumnIndex: 1, RowStart: 0, RowEnd: 1, Format: HeaderFormat},
+		{ColumnIndex: 2, RowStart: 0, RowEnd: 1, Format: HeaderFormat},
+	}, result)
+}
+
+func TestBuiltInFormats(t *testing.T) {
+	assert.Equal(t, "CURRENCY", CurrencyUSD.NumberFormatType)
+	assert.Equal(t, "DATE", DateISO.NumberFormatType)
+	assert.Equal(t, "PERCENT", Percent.NumberFormatType)
+	assert.True(t, BoldFormat.Bold)
+	assert.True(t, HeaderFormat.Bold)
+}
@@ -0,0 +1,352 @@
+package sheets
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	defaultBackoffBase   = 500 * time.Millisecond
+	defaultBackoffFactor = 2.0
+	defaultBackoffCap    = 32 * time.Second
+	defaultMaxRetries    = 5
+	defaultCooldown      = time.Minute
+	cooldownShrinkFactor = 0.5
+)
+
+// Observer receives visibility events from a rateLimitedWrapper, e.g. to feed metrics or logs.
+type Observer interface {
+	// OnRateLimitWait is called once per call, after its token bucket lets it through, with the
+	// time spent waiting for the bucket; that time is close to zero when a token was already free.
+	OnRateLimitWait(op string, waited time.Duration)
+
+	// OnRetry is called every time a call is retried after a 429/503 response from the Sheets API.
+	OnRetry(op string, attempt int, err error, backoff time.Duration)
+}
+
+// noopObserver is used when RateLimitConfig.Observer is not set.
+type noopObserver struct{}
+
+func (noopObserver) OnRateLimitWait(string, time.Duration)     {}
+func (noopObserver) OnRetry(string, int, error, time.Duration) {}
+
+// RateLimitConfig configures the read/write token buckets and the retry behaviour applied by a
+// rateLimitedWrapper.
+//
+// Google Sheets enforces a default quota of 60 read requests and 60 write requests per user per
+// minute; the zero value of RateLimitConfig approximates that limit.
+type RateLimitConfig struct {
+	// ReadQPS and ReadBurst configure the token bucket guarding read calls (e.g. QueryRows).
+	ReadQPS   float64
+	ReadBurst int
+
+	// WriteQPS and WriteBurst configure the token bucket guarding write calls (e.g. InsertRows,
+	// UpdateRows, OverwriteRows, BatchUpdateRows, Clear).
+	WriteQPS   float64
+	WriteBurst int
+
+	// MaxRetries is how many times a call is retried after a 429/503 response before the error is
+	// returned to the caller.
+	MaxRetries int
+
+	// Observer, when set, is notified about rate limit waits and retries.
+	Observer Observer
+}
+
+// DefaultRateLimitConfig returns a RateLimitConfig matching the Google Sheets default quota of
+// 60 requests/user/minute for both reads and writes.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		ReadQPS:    1,
+		ReadBurst:  1,
+		WriteQPS:   1,
+		WriteBurst: 1,
+		MaxRetries: defaultMaxRetries,
+	}
+}
+
+func (c RateLimitConfig) withDefaults() RateLimitConfig {
+	if c.ReadQPS <= 0 {
+		c.ReadQPS = 1
+	}
+	if c.ReadBurst <= 0 {
+		c.ReadBurst = 1
+	}
+	if c.WriteQPS <= 0 {
+		c.WriteQPS = 1
+	}
+	if c.WriteBurst <= 0 {
+		c.WriteBurst = 1
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+	if c.Observer == nil {
+		c.Observer = noopObserver{}
+	}
+	return c
+}
+
+// rawWrapper mirrors the sheetsWrapper interface expected by the root package. It is declared here
+// (rather than imported) to avoid a dependency cycle, since the root package already imports this one.
+type rawWrapper interface {
+	CreateSpreadsheet(ctx context.Context, title string) (string, error)
+	GetSheetNameToID(ctx context.Context, spreadsheetID string) (map[string]int64, error)
+	CreateSheet(ctx context.Context, spreadsheetID string, sheetName string) error
+	DeleteSheets(ctx context.Context, spreadsheetID string, sheetIDs []int64) error
+	InsertRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error)
+	OverwriteRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error)
+	UpdateRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (UpdateRowsResult, error)
+	BatchUpdateRows(ctx context.Context, spreadsheetID string, requests []BatchUpdateRowsRequest) (BatchUpdateRowsResult, error)
+	QueryRows(ctx context.Context, spreadsheetID string, sheetName string, query string, skipHeader bool) (QueryRowsResult, error)
+	Clear(ctx context.Context, spreadsheetID string, ranges []string) ([]string, error)
+	AppendDimension(ctx context.Context, spreadsheetID string, sheetID int64, dimension string, length int64) error
+	FormatCells(ctx context.Context, spreadsheetID string, sheetID int64, formatter CellFormatter) error
+	FreezeHeaderRow(ctx context.Context, spreadsheetID string, sheetID int64) error
+}
+
+// cooldownLimiter wraps a rate.Limiter whose rate is temporarily shrunk after a retryable failure.
+// Every mutation of the limiter's rate, and the decision of when to restore it, happens under a
+// single mutex, so concurrent callers (the whole point of sharing a limiter) and repeated failures
+// within or across calls cannot race each other into a permanently under- or over-throttled state.
+type cooldownLimiter struct {
+	mu       sync.Mutex
+	limiter  *rate.Limiter
+	baseRate rate.Limit
+
+	// cooldownUntil is the time at which the shrunk rate should be restored to baseRate. It is
+	// pushed forward by every new failure instead of scheduling a separate restore timer per
+	// failure, so the limiter always converges back to exactly baseRate once things are quiet.
+	cooldownUntil time.Time
+}
+
+func newCooldownLimiter(qps float64, burst int) *cooldownLimiter {
+	base := rate.Limit(qps)
+	return &cooldownLimiter{limiter: rate.NewLimiter(base, burst), baseRate: base}
+}
+
+func (l *cooldownLimiter) wait(ctx context.Context) error {
+	l.restoreIfCooldownElapsed()
+	return l.limiter.Wait(ctx)
+}
+
+func (l *cooldownLimiter) shrink() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limiter.SetLimit(l.limiter.Limit() * rate.Limit(cooldownShrinkFactor))
+	l.cooldownUntil = time.Now().Add(defaultCooldown)
+}
+
+func (l *cooldownLimiter) restoreIfCooldownElapsed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cooldownUntil.IsZero() || time.Now().Before(l.cooldownUntil) {
+		return
+	}
+	l.limiter.SetLimit(l.baseRate)
+	l.cooldownUntil = time.Time{}
+}
+
+// rateLimitedWrapper decorates a rawWrapper with client-side rate limiting (to stay under Google
+// Sheets' per-minute read/write quota) and exponential backoff with jitter on 429/503 responses.
+type rateLimitedWrapper struct {
+	inner  rawWrapper
+	cfg    RateLimitConfig
+	reads  *cooldownLimiter
+	writes *cooldownLimiter
+}
+
+// NewRateLimitedWrapper wraps inner with read/write token buckets and retry-with-backoff behaviour
+// configured by cfg.
+func NewRateLimitedWrapper(inner rawWrapper, cfg RateLimitConfig) *rateLimitedWrapper {
+	cfg = cfg.withDefaults()
+	return &rateLimitedWrapper{
+		inner:  inner,
+		cfg:    cfg,
+		reads:  newCooldownLimiter(cfg.ReadQPS, cfg.ReadBurst),
+		writes: newCooldownLimiter(cfg.WriteQPS, cfg.WriteBurst),
+	}
+}
+
+func (w *rateLimitedWrapper) CreateSpreadsheet(ctx context.Context, title string) (string, error) {
+	var result string
+	err := w.doWrite(ctx, "CreateSpreadsheet", func() error {
+		var innerErr error
+		result, innerErr = w.inner.CreateSpreadsheet(ctx, title)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) GetSheetNameToID(ctx context.Context, spreadsheetID string) (map[string]int64, error) {
+	var result map[string]int64
+	err := w.doRead(ctx, "GetSheetNameToID", func() error {
+		var innerErr error
+		result, innerErr = w.inner.GetSheetNameToID(ctx, spreadsheetID)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) CreateSheet(ctx context.Context, spreadsheetID string, sheetName string) error {
+	return w.doWrite(ctx, "CreateSheet", func() error {
+		return w.inner.CreateSheet(ctx, spreadsheetID, sheetName)
+	})
+}
+
+func (w *rateLimitedWrapper) DeleteSheets(ctx context.Context, spreadsheetID string, sheetIDs []int64) error {
+	return w.doWrite(ctx, "DeleteSheets", func() error {
+		return w.inner.DeleteSheets(ctx, spreadsheetID, sheetIDs)
+	})
+}
+
+func (w *rateLimitedWrapper) InsertRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error) {
+	var result InsertRowsResult
+	err := w.doWrite(ctx, "InsertRows", func() error {
+		var innerErr error
+		result, innerErr = w.inner.InsertRows(ctx, spreadsheetID, a1Range, values)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) OverwriteRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error) {
+	var result InsertRowsResult
+	err := w.doWrite(ctx, "OverwriteRows", func() error {
+		var innerErr error
+		result, innerErr = w.inner.OverwriteRows(ctx, spreadsheetID, a1Range, values)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) UpdateRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (UpdateRowsResult, error) {
+	var result UpdateRowsResult
+	err := w.doWrite(ctx, "UpdateRows", func() error {
+		var innerErr error
+		result, innerErr = w.inner.UpdateRows(ctx, spreadsheetID, a1Range, values)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) BatchUpdateRows(ctx context.Context, spreadsheetID string, requests []BatchUpdateRowsRequest) (BatchUpdateRowsResult, error) {
+	var result BatchUpdateRowsResult
+	err := w.doWrite(ctx, "BatchUpdateRows", func() error {
+		var innerErr error
+		result, innerErr = w.inner.BatchUpdateRows(ctx, spreadsheetID, requests)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) QueryRows(ctx context.Context, spreadsheetID string, sheetName string, query string, skipHeader bool) (QueryRowsResult, error) {
+	var result QueryRowsResult
+	err := w.doRead(ctx, "QueryRows", func() error {
+		var innerErr error
+		result, innerErr = w.inner.QueryRows(ctx, spreadsheetID, sheetName, query, skipHeader)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) Clear(ctx context.Context, spreadsheetID string, ranges []string) ([]string, error) {
+	var result []string
+	err := w.doWrite(ctx, "Clear", func() error {
+		var innerErr error
+		result, innerErr = w.inner.Clear(ctx, spreadsheetID, ranges)
+		return innerErr
+	})
+	return result, err
+}
+
+func (w *rateLimitedWrapper) AppendDimension(ctx context.Context, spreadsheetID string, sheetID int64, dimension string, length int64) error {
+	return w.doWrite(ctx, "AppendDimension", func() error {
+		return w.inner.AppendDimension(ctx, spreadsheetID, sheetID, dimension, length)
+	})
+}
+
+func (w *rateLimitedWrapper) FormatCells(ctx context.Context, spreadsheetID string, sheetID int64, formatter CellFormatter) error {
+	return w.doWrite(ctx, "FormatCells", func() error {
+		return w.inner.FormatCells(ctx, spreadsheetID, sheetID, formatter)
+	})
+}
+
+func (w *rateLimitedWrapper) FreezeHeaderRow(ctx context.Context, spreadsheetID string, sheetID int64) error {
+	return w.doWrite(ctx, "FreezeHeaderRow", func() error {
+		return w.inner.FreezeHeaderRow(ctx, spreadsheetID, sheetID)
+	})
+}
+
+func (w *rateLimitedWrapper) doRead(ctx context.Context, op string, call func() error) error {
+	return w.do(ctx, op, w.reads, call)
+}
+
+func (w *rateLimitedWrapper) doWrite(ctx context.Context, op string, call func() error) error {
+	return w.do(ctx, op, w.writes, call)
+}
+
+func (w *rateLimitedWrapper) do(ctx context.Context, op string, limiter *cooldownLimiter, call func() error) error {
+	start := time.Now()
+	if err := limiter.wait(ctx); err != nil {
+		return err
+	}
+	if waited := time.Since(start); waited > 0 {
+		w.cfg.Observer.OnRateLimitWait(op, waited)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= w.cfg.MaxRetries; attempt++ {
+		lastErr = call()
+		if lastErr == nil {
+			return nil
+		}
+		if !isRetryable(lastErr) || attempt == w.cfg.MaxRetries {
+			return lastErr
+		}
+
+		backoff := backoffWithJitter(attempt)
+		limiter.shrink()
+		w.cfg.Observer.OnRetry(op, attempt+1, lastErr, backoff)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return lastErr
+}
+
+func isRetryable(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == 429 || apiErr.Code == 503
+	}
+	return false
+}
+
+func backoffWithJitter(attempt int) time.Duration {
+	backoff := float64(defaultBackoffBase) * pow(defaultBackoffFactor, attempt)
+	if backoff > float64(defaultBackoffCap) {
+		backoff = float64(defaultBackoffCap)
+	}
+	jitter := rand.Float64() * backoff
+	return time.Duration(backoff/2 + jitter/2)
+}
+
+func pow(base float64, exp int) float64 {
+	result := 1.0
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
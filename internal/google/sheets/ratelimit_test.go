@@ -0,0 +1,162 @@
+package sheets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/googleapi"
+)
+
+type fakeRawWrapper struct {
+	insertCalls int
+	insertErrs  []error
+}
+
+func (f *fakeRawWrapper) CreateSpreadsheet(ctx context.Context, title string) (string, error) {
+	return "", nil
+}
+func (f *fakeRawWrapper) GetSheetNameToID(ctx context.Context, spreadsheetID string) (map[string]int64, error) {
+	return nil, nil
+}
+func (f *fakeRawWrapper) CreateSheet(ctx context.Context, spreadsheetID string, sheetName string) error {
+	return nil
+}
+func (f *fakeRawWrapper) DeleteSheets(ctx context.Context, spreadsheetID string, sheetIDs []int64) error {
+	return nil
+}
+
+func (f *fakeRawWrapper) InsertRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error) {
+	err := f.insertErrs[f.insertCalls]
+	f.insertCalls++
+	return InsertRowsResult{}, err
+}
+
+func (f *fakeRawWrapper) OverwriteRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (InsertRowsResult, error) {
+	return InsertRowsResult{}, nil
+}
+func (f *fakeRawWrapper) UpdateRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (UpdateRowsResult, error) {
+	return UpdateRowsResult{}, nil
+}
+func (f *fakeRawWrapper) BatchUpdateRows(ctx context.Context, spreadsheetID string, requests []BatchUpdateRowsRequest) (BatchUpdateRowsResult, error) {
+	return BatchUpdateRowsResult{}, nil
+}
+func (f *fakeRawWrapper) QueryRows(ctx context.Context, spreadsheetID string, sheetName string, query string, skipHeader bool) (QueryRowsResult, error) {
+	return QueryRowsResult{}, nil
+}
+func (f *fakeRawWrapper) Clear(ctx context.Context, spreadsheetID string, ranges []string) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeRawWrapper) AppendDimension(ctx context.Context, spreadsheetID string, sheetID int64, dimension string, length int64) error {
+	return nil
+}
+func (f *fakeRawWrapper) FormatCells(ctx context.Context, spreadsheetID string, sheetID int64, formatter CellFormatter) error {
+	return nil
+}
+func (f *fakeRawWrapper) FreezeHeaderRow(ctx context.Context, spreadsheetID string, sheetID int64) error {
+	return nil
+}
+
+type recordingObserver struct {
+	waits   int
+	retries int
+}
+
+func (o *recordingObserver) OnRateLimitWait(op string, waited time.Duration) { o.waits++ }
+func (o *recordingObserver) OnRetry(op string, attempt int, err error, backoff time.Duration) {
+	o.retries++
+}
+
+func TestRateLimitedWrapper_RetriesOn429(t *testing.T) {
+	inner := &fakeRawWrapper{insertErrs: []error{
+		&googleapi.Error{Code: 429},
+		&googleapi.Error{Code: 503},
+		nil,
+	}}
+	observer := &recordingObserver{}
+	cfg := RateLimitConfig{ReadQPS: 100, ReadBurst: 100, WriteQPS: 100, WriteBurst: 100, MaxRetries: 5, Observer: observer}
+	wrapper := NewRateLimitedWrapper(inner, cfg)
+
+	_, err := wrapper.InsertRows(context.Background(), "sheet", "A1:B2", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, inner.insertCalls)
+	assert.Equal(t, 2, observer.retries)
+}
+
+func TestRateLimitedWrapper_NonRetryableErrorStopsImmediately(t *testing.T) {
+	inner := &fakeRawWrapper{insertErrs: []error{errors.New("some non-retryable error")}}
+	cfg := RateLimitConfig{ReadQPS: 100, ReadBurst: 100, WriteQPS: 100, WriteBurst: 100, MaxRetries: 5}
+	wrapper := NewRateLimitedWrapper(inner, cfg)
+
+	_, err := wrapper.InsertRows(context.Background(), "sheet", "A1:B2", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, inner.insertCalls)
+}
+
+func TestRateLimitedWrapper_ExhaustsRetries(t *testing.T) {
+	inner := &fakeRawWrapper{insertErrs: []error{
+		&googleapi.Error{Code: 429},
+		&googleapi.Error{Code: 429},
+	}}
+	cfg := RateLimitConfig{ReadQPS: 100, ReadBurst: 100, WriteQPS: 100, WriteBurst: 100, MaxRetries: 1}
+	wrapper := NewRateLimitedWrapper(inner, cfg)
+
+	_, err := wrapper.InsertRows(context.Background(), "sheet", "A1:B2", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, inner.insertCalls)
+}
+
+func TestCooldownLimiter_ShrinkAndRestore(t *testing.T) {
+	l := newCooldownLimiter(10, 10)
+	assert.Equal(t, rate.Limit(10), l.limiter.Limit())
+
+	l.shrink()
+	assert.Equal(t, rate.Limit(5), l.limiter.Limit())
+
+	// Repeated failures (e.g. from concurrent callers) only ever shrink relative to the current
+	// rate and push the cooldown deadline forward; they never stack independent restores.
+	l.shrink()
+	assert.Equal(t, rate.Limit(2.5), l.limiter.Limit())
+
+	// Before the cooldown window has elapsed, waiting must not restore the rate early.
+	l.restoreIfCooldownElapsed()
+	assert.Equal(t, rate.Limit(2.5), l.limiter.Limit())
+
+	// Once the cooldown window has elapsed, the rate snaps back to exactly the base rate.
+	l.mu.Lock()
+	l.cooldownUntil = time.Now().Add(-time.Second)
+	l.mu.Unlock()
+
+	l.restoreIfCooldownElapsed()
+	assert.Equal(t, rate.Limit(10), l.limiter.Limit())
+}
+
+func TestCooldownLimiter_ConcurrentShrinksDoNotRace(t *testing.T) {
+	l := newCooldownLimiter(100, 100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.shrink()
+		}()
+	}
+	wg.Wait()
+
+	// No assertion on the exact resulting rate (it depends on goroutine interleaving), just that
+	// concurrent access is race-free (verified by running this test with -race) and leaves the
+	// limiter in a sane, positive state rather than corrupted.
+	assert.True(t, l.limiter.Limit() > 0)
+}
+
+func TestDefaultRateLimitConfig(t *testing.T) {
+	cfg := DefaultRateLimitConfig()
+	assert.Equal(t, float64(1), cfg.ReadQPS)
+	assert.Equal(t, float64(1), cfg.WriteQPS)
+	assert.Equal(t, defaultMaxRetries, cfg.MaxRetries)
+}
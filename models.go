@@ -3,7 +3,9 @@ package freeleh
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/FreeLeh/GoFreeLeh/internal/google/sheets"
 )
@@ -19,10 +21,8 @@ const (
 	OrderByAsc  OrderBy = "ASC"
 	OrderByDesc OrderBy = "DESC"
 
-	// Currently limited to 26.
-	// Otherwise, the sheet creation must extend the column as well to make the rowGetIndicesQueryTemplate formula works.
-	// TODO(edocsss): add an option to extend the number of columns.
-	maxColumn = 26
+	// ridColumnCount accounts for the "_rid" column FreeDB prepends to every row store schema.
+	ridColumnCount = 1
 
 	scratchpadBooked          = "BOOKED"
 	scratchpadSheetNameSuffix = "_scratch"
@@ -42,18 +42,61 @@ const (
 	naValue    = "#N/A"
 	errorValue = "#ERROR!"
 	rowTsCol   = "_ts"
+	rowIdxCol  = "_rid"
 )
 
 var (
 	ErrKeyNotFound = errors.New("error key not found")
 
-	defaultRowHeaderRange    = "A1:" + generateColumnName(maxColumn-1) + "1"
-	defaultRowFullTableRange = "A2:" + generateColumnName(maxColumn-1)
-	rowDeleteRangeTemplate   = "A%d:" + generateColumnName(maxColumn-1) + "%d"
-
 	googleSheetSelectStmtStringKeyword = regexp.MustCompile("^(date|datetime|timeofday)")
 )
 
+// columnCount returns how many columns a row store schema with the given user-defined columns
+// needs, including the "_rid" column FreeDB manages internally. It replaces the old hard-coded
+// 26 column ceiling: the sheet is now extended (see sheetsWrapper.AppendDimension) to fit however
+// many columns the schema actually declares.
+func columnCount(numColumns int) int {
+	return numColumns + ridColumnCount
+}
+
+// rowHeaderRange returns the A1 range spanning the header row for a schema with the given column count.
+func rowHeaderRange(colCount int) string {
+	return "A1:" + generateColumnName(colCount-1) + "1"
+}
+
+// rowFullTableRange returns the A1 range spanning every data row (i.e. excluding the header) for a
+// schema with the given column count.
+func rowFullTableRange(colCount int) string {
+	return "A2:" + generateColumnName(colCount-1)
+}
+
+// rowDeleteRange returns the A1 range spanning a single data row (identified by its 1-indexed sheet
+// row number) for a schema with the given column count.
+func rowDeleteRange(colCount int, row int) string {
+	return fmt.Sprintf("A%d:%s%d", row, generateColumnName(colCount-1), row)
+}
+
+// generateColumnName converts a 0-indexed column position into its A1 notation column name,
+// handling multi-letter names once the position goes past "Z" (e.g. 25 -> "Z", 26 -> "AA", 27 -> "AB").
+func generateColumnName(idx int) string {
+	if idx < 0 {
+		return ""
+	}
+
+	var name strings.Builder
+	for idx >= 0 {
+		name.WriteByte(byte('A' + idx%26))
+		idx = idx/26 - 1
+	}
+
+	reversed := name.String()
+	runes := []rune(reversed)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
 type Codec interface {
 	Encode(value []byte) (string, error)
 	Decode(value string) ([]byte, error)
@@ -70,6 +113,19 @@ type sheetsWrapper interface {
 	BatchUpdateRows(ctx context.Context, spreadsheetID string, requests []sheets.BatchUpdateRowsRequest) (sheets.BatchUpdateRowsResult, error)
 	QueryRows(ctx context.Context, spreadsheetID string, sheetName string, query string, skipHeader bool) (sheets.QueryRowsResult, error)
 	Clear(ctx context.Context, spreadsheetID string, ranges []string) ([]string, error)
+
+	// AppendDimension grows the given sheet by "length" columns (or rows), so schemas that need
+	// more than the sheet's current column count can still be created/extended.
+	AppendDimension(ctx context.Context, spreadsheetID string, sheetID int64, dimension string, length int64) error
+
+	// FormatCells applies the given CellFormatter's column formats to the given sheet via
+	// spreadsheets.batchUpdate, so writers can opt into typed formatting (currency, dates, bold
+	// headers, ...) instead of shipping raw values only.
+	FormatCells(ctx context.Context, spreadsheetID string, sheetID int64, formatter sheets.CellFormatter) error
+
+	// FreezeHeaderRow freezes the first row of the given sheet, via an UpdateSheetPropertiesRequest
+	// setting gridProperties.frozenRowCount to 1.
+	FreezeHeaderRow(ctx context.Context, spreadsheetID string, sheetID int64) error
 }
 
 type colIdx struct {
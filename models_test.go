@@ -0,0 +1,48 @@
+package freeleh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateColumnName(t *testing.T) {
+	testCases := []struct {
+		idx      int
+		expected string
+	}{
+		{0, "A"},
+		{1, "B"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, generateColumnName(tc.idx))
+	}
+}
+
+func TestColumnCount(t *testing.T) {
+	assert.Equal(t, 3, columnCount(2))
+	assert.Equal(t, 31, columnCount(30))
+	assert.Equal(t, 101, columnCount(100))
+}
+
+func TestRowHeaderRange_30Columns(t *testing.T) {
+	count := columnCount(30)
+	assert.Equal(t, "A1:AE1", rowHeaderRange(count))
+	assert.Equal(t, "A2:AE", rowFullTableRange(count))
+	assert.Equal(t, "A5:AE5", rowDeleteRange(count, 5))
+}
+
+func TestRowHeaderRange_100Columns(t *testing.T) {
+	count := columnCount(100)
+	assert.Equal(t, "A1:CW1", rowHeaderRange(count))
+	assert.Equal(t, "A2:CW", rowFullTableRange(count))
+	assert.Equal(t, "A10:CW10", rowDeleteRange(count, 10))
+}
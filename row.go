@@ -0,0 +1,150 @@
+package freeleh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FreeLeh/GoFreeLeh/internal/google/sheets"
+)
+
+// GoogleSheetRowStoreConfig configures a GoogleSheetRowStore.
+type GoogleSheetRowStoreConfig struct {
+	// Columns are the user-defined column names, in the order they should appear in the sheet
+	// (after the "_rid" column FreeDB manages internally).
+	Columns []string
+
+	// ColumnFormats optionally maps a column name (from Columns) to the CellFormat it should be
+	// rendered with. Columns left out of this map keep the sheet's default formatting.
+	//
+	// Formats are applied once, when the sheet is first created, not on every subsequent row
+	// write — rows inserted/updated/overwritten afterwards go through the plain values API and
+	// simply keep the formatting Sheets already has in place for those cells.
+	ColumnFormats map[string]sheets.CellFormat
+
+	// RateLimitConfig, when set, wraps the store's Sheets client with client-side rate limiting and
+	// retry-with-backoff. Left nil, the wrapper passed to NewGoogleSheetRowStore is used as-is.
+	RateLimitConfig *sheets.RateLimitConfig
+}
+
+// GoogleSheetRowStore stores rows of structured data in a single sheet, modelled as a table whose
+// columns are declared by GoogleSheetRowStoreConfig.Columns.
+type GoogleSheetRowStore struct {
+	wrapper       sheetsWrapper
+	spreadsheetID string
+	sheetID       int64
+	sheetName     string
+	config        GoogleSheetRowStoreConfig
+}
+
+// NewGoogleSheetRowStore creates a GoogleSheetRowStore backed by an existing sheet, bootstrapping it
+// (widening its column count to fit the schema, if necessary) before handing it back.
+//
+// wrapper is the low-level Sheets client, sheetID/currentColumnCount identify the target sheet and
+// its column count as last observed by the caller (e.g. from spreadsheet metadata). isNewSheet must
+// only be true when the caller just created sheetID itself (e.g. via sheetsWrapper.CreateSheet) —
+// it gates the one-time header row write/format/freeze pass, which would otherwise clobber an
+// already-initialized sheet's header (and its formatting) on every restart.
+func NewGoogleSheetRowStore(ctx context.Context, wrapper sheetsWrapper, spreadsheetID string, sheetName string, sheetID int64, currentColumnCount int, isNewSheet bool, config GoogleSheetRowStoreConfig) (*GoogleSheetRowStore, error) {
+	if config.RateLimitConfig != nil {
+		wrapper = sheets.NewRateLimitedWrapper(wrapper, *config.RateLimitConfig)
+	}
+
+	store := &GoogleSheetRowStore{
+		wrapper:       wrapper,
+		spreadsheetID: spreadsheetID,
+		sheetID:       sheetID,
+		sheetName:     sheetName,
+		config:        config,
+	}
+	if err := store.bootstrap(ctx, currentColumnCount, isNewSheet); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// bootstrap widens the sheet to fit the configured schema whenever it has fewer columns than
+// required (replacing the old hard-coded 26-column ceiling). When isNewSheet is true, it also
+// writes the header row across that widened range, bolds and freezes it, and applies any
+// user-declared ColumnFormats — this part only ever runs once, at sheet creation, not on every
+// subsequent construction against the same sheet.
+func (s *GoogleSheetRowStore) bootstrap(ctx context.Context, currentColumnCount int, isNewSheet bool) error {
+	required := s.columnCount()
+	if currentColumnCount < required {
+		if err := s.wrapper.AppendDimension(ctx, s.spreadsheetID, s.sheetID, "COLUMNS", int64(required-currentColumnCount)); err != nil {
+			return fmt.Errorf("failed extending sheet %q to %d columns: %w", s.sheetName, required, err)
+		}
+	}
+
+	if !isNewSheet {
+		return nil
+	}
+
+	header := make([]interface{}, required)
+	header[0] = rowIdxCol
+	for i, col := range s.config.Columns {
+		header[i+1] = col
+	}
+	if _, err := s.wrapper.OverwriteRows(ctx, s.spreadsheetID, s.headerRange(), [][]interface{}{header}); err != nil {
+		return fmt.Errorf("failed writing the header row of sheet %q: %w", s.sheetName, err)
+	}
+
+	if err := s.wrapper.FormatCells(ctx, s.spreadsheetID, s.sheetID, sheets.HeaderRowFormatter(required)); err != nil {
+		return fmt.Errorf("failed formatting the header row of sheet %q: %w", s.sheetName, err)
+	}
+	if err := s.wrapper.FreezeHeaderRow(ctx, s.spreadsheetID, s.sheetID); err != nil {
+		return fmt.Errorf("failed freezing the header row of sheet %q: %w", s.sheetName, err)
+	}
+
+	if len(s.config.ColumnFormats) > 0 {
+		// NewColumnFormatter applies each column's CellFormat once, as a blanket styling pass,
+		// rather than formatting it on every later value write. Subsequent InsertRows/UpdateRows/
+		// OverwriteRows calls always go through the plain values API: Sheets retains a cell's
+		// existing format across value-only writes, so the formatting set up here survives, but
+		// this is a one-time-at-creation styling pass, not the per-write UpdateCellsRequest path.
+		formatter := sheets.NewColumnFormatter(s.config.ColumnFormats, s.colIndex(), 1, 0)
+		if err := s.wrapper.FormatCells(ctx, s.spreadsheetID, s.sheetID, formatter); err != nil {
+			return fmt.Errorf("failed applying column formats of sheet %q: %w", s.sheetName, err)
+		}
+	}
+	return nil
+}
+
+// colIndex maps each configured column name to its 0-indexed column position, accounting for the
+// "_rid" column FreeDB prepends.
+func (s *GoogleSheetRowStore) colIndex() map[string]int {
+	idx := make(map[string]int, len(s.config.Columns))
+	for i, col := range s.config.Columns {
+		idx[col] = i + 1
+	}
+	return idx
+}
+
+// columnCount is how many columns this store's schema needs, including the "_rid" column.
+func (s *GoogleSheetRowStore) columnCount() int {
+	return columnCount(len(s.config.Columns))
+}
+
+func (s *GoogleSheetRowStore) headerRange() string {
+	return rowHeaderRange(s.columnCount())
+}
+
+func (s *GoogleSheetRowStore) fullTableRange() string {
+	return rowFullTableRange(s.columnCount())
+}
+
+func (s *GoogleSheetRowStore) deleteRange(row int) string {
+	return rowDeleteRange(s.columnCount(), row)
+}
+
+// InsertRows appends one or more rows (already laid out in schema column order, with the "_rid"
+// formula prepended) to the end of the table.
+func (s *GoogleSheetRowStore) InsertRows(ctx context.Context, rows [][]interface{}) (sheets.InsertRowsResult, error) {
+	return s.wrapper.InsertRows(ctx, s.spreadsheetID, s.fullTableRange(), rows)
+}
+
+// DeleteRow clears the given 1-indexed sheet row, spanning exactly the columns this store's schema
+// occupies (rather than the whole, now dynamically-sized, row).
+func (s *GoogleSheetRowStore) DeleteRow(ctx context.Context, row int) error {
+	_, err := s.wrapper.Clear(ctx, s.spreadsheetID, []string{s.deleteRange(row)})
+	return err
+}
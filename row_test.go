@@ -0,0 +1,148 @@
+package freeleh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FreeLeh/GoFreeLeh/internal/google/sheets"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRowWrapper struct {
+	appendDimensionCalls []int64
+	overwriteRange       string
+	overwriteValues      [][]interface{}
+	insertRange          string
+	clearRanges          []string
+	formatCalls          []sheets.CellFormatter
+	freezeHeaderRowCalls int
+}
+
+func (f *fakeRowWrapper) CreateSpreadsheet(ctx context.Context, title string) (string, error) {
+	return "", nil
+}
+func (f *fakeRowWrapper) GetSheetNameToID(ctx context.Context, spreadsheetID string) (map[string]int64, error) {
+	return nil, nil
+}
+func (f *fakeRowWrapper) CreateSheet(ctx context.Context, spreadsheetID string, sheetName string) error {
+	return nil
+}
+func (f *fakeRowWrapper) DeleteSheets(ctx context.Context, spreadsheetID string, sheetIDs []int64) error {
+	return nil
+}
+func (f *fakeRowWrapper) InsertRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (sheets.InsertRowsResult, error) {
+	f.insertRange = a1Range
+	return sheets.InsertRowsResult{}, nil
+}
+func (f *fakeRowWrapper) OverwriteRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (sheets.InsertRowsResult, error) {
+	f.overwriteRange = a1Range
+	f.overwriteValues = values
+	return sheets.InsertRowsResult{}, nil
+}
+func (f *fakeRowWrapper) UpdateRows(ctx context.Context, spreadsheetID string, a1Range string, values [][]interface{}) (sheets.UpdateRowsResult, error) {
+	return sheets.UpdateRowsResult{}, nil
+}
+func (f *fakeRowWrapper) BatchUpdateRows(ctx context.Context, spreadsheetID string, requests []sheets.BatchUpdateRowsRequest) (sheets.BatchUpdateRowsResult, error) {
+	return sheets.BatchUpdateRowsResult{}, nil
+}
+func (f *fakeRowWrapper) QueryRows(ctx context.Context, spreadsheetID string, sheetName string, query string, skipHeader bool) (sheets.QueryRowsResult, error) {
+	return sheets.QueryRowsResult{}, nil
+}
+func (f *fakeRowWrapper) Clear(ctx context.Context, spreadsheetID string, ranges []string) ([]string, error) {
+	f.clearRanges = ranges
+	return ranges, nil
+}
+func (f *fakeRowWrapper) AppendDimension(ctx context.Context, spreadsheetID string, sheetID int64, dimension string, length int64) error {
+	f.appendDimensionCalls = append(f.appendDimensionCalls, length)
+	return nil
+}
+func (f *fakeRowWrapper) FormatCells(ctx context.Context, spreadsheetID string, sheetID int64, formatter sheets.CellFormatter) error {
+	f.formatCalls = append(f.formatCalls, formatter)
+	return nil
+}
+func (f *fakeRowWrapper) FreezeHeaderRow(ctx context.Context, spreadsheetID string, sheetID int64) error {
+	f.freezeHeaderRowCalls++
+	return nil
+}
+
+func TestNewGoogleSheetRowStore_NewSheetWidensAndWritesHeader(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{Columns: []string{"name", "age", "dob"}}
+
+	_, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 2, true, config)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2}, wrapper.appendDimensionCalls, "sheet only has 2 columns but the schema needs 4 (3 + _rid)")
+	assert.Equal(t, "A1:D1", wrapper.overwriteRange)
+	assert.Equal(t, [][]interface{}{{rowIdxCol, "name", "age", "dob"}}, wrapper.overwriteValues)
+	assert.Equal(t, 1, wrapper.freezeHeaderRowCalls)
+	assert.Len(t, wrapper.formatCalls, 1, "only the bold header format, since no ColumnFormats were configured")
+}
+
+func TestNewGoogleSheetRowStore_ExistingSheetSkipsHeaderPass(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{Columns: []string{"name", "age", "dob"}}
+
+	_, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 2, false, config)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2}, wrapper.appendDimensionCalls, "widening still happens regardless of isNewSheet")
+	assert.Empty(t, wrapper.overwriteRange, "the header row must not be rewritten against an already-initialized sheet")
+	assert.Zero(t, wrapper.freezeHeaderRowCalls)
+	assert.Empty(t, wrapper.formatCalls)
+}
+
+func TestNewGoogleSheetRowStore_AppliesColumnFormats(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{
+		Columns:       []string{"name", "balance"},
+		ColumnFormats: map[string]sheets.CellFormat{"balance": sheets.CurrencyUSD},
+	}
+
+	_, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 10, true, config)
+	assert.Nil(t, err)
+	assert.Len(t, wrapper.formatCalls, 2, "the bold header format, plus the configured column formats")
+
+	formats := wrapper.formatCalls[1].Format(42)
+	assert.Equal(t, []sheets.ColumnCellFormat{{ColumnIndex: 2, RowStart: 1, RowEnd: 0, Format: sheets.CurrencyUSD}}, formats)
+}
+
+func TestNewGoogleSheetRowStore_SkipsWideningWhenAlreadyWideEnough(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{Columns: []string{"name", "age", "dob"}}
+
+	_, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 10, false, config)
+	assert.Nil(t, err)
+	assert.Empty(t, wrapper.appendDimensionCalls)
+}
+
+func TestGoogleSheetRowStore_InsertRows(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{Columns: make([]string, 99)}
+	store, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 100, false, config)
+	assert.Nil(t, err)
+
+	_, err = store.InsertRows(context.Background(), [][]interface{}{{rowIdxFormulaPlaceholder}})
+	assert.Nil(t, err)
+	assert.Equal(t, "A2:CV", wrapper.insertRange, "100 columns (99 + _rid) -> last column index 99 -> CV")
+}
+
+func TestNewGoogleSheetRowStore_WrapsWithRateLimiterWhenConfigured(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	rlCfg := sheets.DefaultRateLimitConfig()
+	config := GoogleSheetRowStoreConfig{Columns: []string{"name"}, RateLimitConfig: &rlCfg}
+
+	store, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 10, false, config)
+	assert.Nil(t, err)
+	assert.NotSame(t, wrapper, store.wrapper, "the store should route calls through the rate-limited wrapper, not the raw one directly")
+}
+
+func TestGoogleSheetRowStore_DeleteRow(t *testing.T) {
+	wrapper := &fakeRowWrapper{}
+	config := GoogleSheetRowStoreConfig{Columns: []string{"name", "age", "dob"}}
+	store, err := NewGoogleSheetRowStore(context.Background(), wrapper, "spreadsheet1", "Sheet1", 42, 10, false, config)
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.DeleteRow(context.Background(), 5))
+	assert.Equal(t, []string{"A5:D5"}, wrapper.clearRanges)
+}
+
+const rowIdxFormulaPlaceholder = "=ROW()"